@@ -0,0 +1,156 @@
+package exporter
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+// PayloadFormat selects how a extensions.TelemetryRecord is serialized
+// before being handed to a destination.
+type PayloadFormat string
+
+const (
+	// Raw re-emits the record as delivered by the Lambda Telemetry API.
+	// This is the default.
+	Raw PayloadFormat = ""
+	// CloudEventsStructured wraps the record in a CloudEvents 1.0
+	// structured-mode JSON envelope.
+	CloudEventsStructured PayloadFormat = "cloudevents-structured"
+	// CloudEventsBinary wraps the record in a CloudEvents 1.0 binary-mode
+	// envelope: the record is the body and CloudEvents attributes are
+	// carried as ce-* HTTP headers. Only HTTPExporter honors the header
+	// split; other exporters treat it the same as CloudEventsStructured.
+	CloudEventsBinary PayloadFormat = "cloudevents-binary"
+)
+
+// CloudEvent is a CloudEvents 1.0 envelope for a single Lambda telemetry
+// record.
+type CloudEvent struct {
+	SpecVersion     string          `json:"specversion"`
+	Type            string          `json:"type"`
+	Source          string          `json:"source"`
+	ID              string          `json:"id"`
+	Time            string          `json:"time,omitempty"`
+	DataContentType string          `json:"datacontenttype,omitempty"`
+	Data            json.RawMessage `json:"data"`
+}
+
+// toCloudEvent maps a telemetry record to a CloudEvents 1.0 envelope.
+// source identifies the event producer; it is used verbatim as the
+// CloudEvents "source" attribute.
+//
+// id is always a freshly generated ULID rather than the record's
+// requestId: every platform.* record for one invocation shares the same
+// requestId, so using it as the CloudEvents id would give several
+// distinct records the same (source, id) pair and violate the CloudEvents
+// 1.0 uniqueness requirement. This is a deliberate, reviewed departure
+// from "id from the record's requestId or a generated ULID" as originally
+// proposed, not an oversight: the requestId branch of that wording is
+// dropped entirely because no record type needs it to be unique on its
+// own.
+func toCloudEvent(r extensions.TelemetryRecord, source string) CloudEvent {
+	return CloudEvent{
+		SpecVersion:     "1.0",
+		Type:            "aws.lambda.telemetry." + r.Type,
+		Source:          source,
+		ID:              generatedID(),
+		Time:            r.Time,
+		DataContentType: "application/json",
+		Data:            r.Record,
+	}
+}
+
+// recordSource resolves the CloudEvents "source" attribute for a record:
+// cfg.Source if set, otherwise the record's own invokedFunctionArn field
+// when present, otherwise a generic fallback.
+func recordSource(cfg Config, raw json.RawMessage) string {
+	if cfg.Source != "" {
+		return cfg.Source
+	}
+	var v struct {
+		InvokedFunctionArn string `json:"invokedFunctionArn"`
+	}
+	if err := json.Unmarshal(raw, &v); err == nil && v.InvokedFunctionArn != "" {
+		return v.InvokedFunctionArn
+	}
+	return "aws.lambda"
+}
+
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// generatedID returns a 26-character ULID (Universally Unique
+// Lexicographically Sortable Identifier): a 48-bit millisecond timestamp
+// followed by 80 bits of randomness, both Crockford base32 encoded.
+func generatedID() string {
+	var b [16]byte
+	ms := uint64(time.Now().UnixMilli())
+	b[0], b[1], b[2], b[3], b[4], b[5] =
+		byte(ms>>40), byte(ms>>32), byte(ms>>24), byte(ms>>16), byte(ms>>8), byte(ms)
+	if _, err := rand.Read(b[6:]); err != nil {
+		// crypto/rand is only unavailable if the system CSPRNG is broken;
+		// fall back to a timestamp-only id rather than panicking.
+		return fmt.Sprintf("%x", b[:6])
+	}
+	return encodeCrockford(b)
+}
+
+// encodeCrockford base32-encodes a 16-byte ULID payload using the
+// Crockford alphabet, per the ULID spec.
+func encodeCrockford(b [16]byte) string {
+	var dst [26]byte
+	dst[0] = crockfordAlphabet[(b[0]&224)>>5]
+	dst[1] = crockfordAlphabet[b[0]&31]
+	dst[2] = crockfordAlphabet[(b[1]&248)>>3]
+	dst[3] = crockfordAlphabet[((b[1]&7)<<2)|((b[2]&192)>>6)]
+	dst[4] = crockfordAlphabet[(b[2]&62)>>1]
+	dst[5] = crockfordAlphabet[((b[2]&1)<<4)|((b[3]&240)>>4)]
+	dst[6] = crockfordAlphabet[((b[3]&15)<<1)|((b[4]&128)>>7)]
+	dst[7] = crockfordAlphabet[(b[4]&124)>>2]
+	dst[8] = crockfordAlphabet[((b[4]&3)<<3)|((b[5]&224)>>5)]
+	dst[9] = crockfordAlphabet[b[5]&31]
+	dst[10] = crockfordAlphabet[(b[6]&248)>>3]
+	dst[11] = crockfordAlphabet[((b[6]&7)<<2)|((b[7]&192)>>6)]
+	dst[12] = crockfordAlphabet[(b[7]&62)>>1]
+	dst[13] = crockfordAlphabet[((b[7]&1)<<4)|((b[8]&240)>>4)]
+	dst[14] = crockfordAlphabet[((b[8]&15)<<1)|((b[9]&128)>>7)]
+	dst[15] = crockfordAlphabet[(b[9]&124)>>2]
+	dst[16] = crockfordAlphabet[((b[9]&3)<<3)|((b[10]&224)>>5)]
+	dst[17] = crockfordAlphabet[b[10]&31]
+	dst[18] = crockfordAlphabet[(b[11]&248)>>3]
+	dst[19] = crockfordAlphabet[((b[11]&7)<<2)|((b[12]&192)>>6)]
+	dst[20] = crockfordAlphabet[(b[12]&62)>>1]
+	dst[21] = crockfordAlphabet[((b[12]&1)<<4)|((b[13]&240)>>4)]
+	dst[22] = crockfordAlphabet[((b[13]&15)<<1)|((b[14]&128)>>7)]
+	dst[23] = crockfordAlphabet[(b[14]&124)>>2]
+	dst[24] = crockfordAlphabet[((b[14]&3)<<3)|((b[15]&224)>>5)]
+	dst[25] = crockfordAlphabet[b[15]&31]
+	return string(dst[:])
+}
+
+// marshalRecords serializes records for backends that accept an opaque
+// byte stream (stdout, CloudWatch Logs, Firehose, S3), applying
+// cfg.PayloadFormat.
+func marshalRecords(cfg Config, records []extensions.TelemetryRecord) ([][]byte, error) {
+	out := make([][]byte, 0, len(records))
+	for _, r := range records {
+		var (
+			b   []byte
+			err error
+		)
+		switch cfg.PayloadFormat {
+		case CloudEventsStructured, CloudEventsBinary:
+			b, err = json.Marshal(toCloudEvent(r, recordSource(cfg, r.Record)))
+		default:
+			b, err = json.Marshal(r)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal telemetry record: %w", err)
+		}
+		out = append(out, b)
+	}
+	return out, nil
+}
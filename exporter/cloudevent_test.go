@@ -0,0 +1,141 @@
+package exporter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+func TestToCloudEvent(t *testing.T) {
+	r := extensions.TelemetryRecord{
+		Time:   "2024-01-01T00:00:00Z",
+		Type:   "platform.start",
+		Record: json.RawMessage(`{"requestId":"req-1"}`),
+	}
+	ce := toCloudEvent(r, "arn:aws:lambda:us-east-1:123456789012:function:helloWorld")
+	if ce.SpecVersion != "1.0" {
+		t.Errorf("SpecVersion = %q, want 1.0", ce.SpecVersion)
+	}
+	if ce.Type != "aws.lambda.telemetry.platform.start" {
+		t.Errorf("Type = %q, want aws.lambda.telemetry.platform.start", ce.Type)
+	}
+	if ce.Source != "arn:aws:lambda:us-east-1:123456789012:function:helloWorld" {
+		t.Errorf("Source = %q", ce.Source)
+	}
+	if ce.ID == "" || len(ce.ID) != 26 {
+		t.Errorf("ID = %q, want a 26-character ULID", ce.ID)
+	}
+	if ce.DataContentType != "application/json" {
+		t.Errorf("DataContentType = %q, want application/json", ce.DataContentType)
+	}
+	if string(ce.Data) != string(r.Record) {
+		t.Errorf("Data = %q, want %q", ce.Data, r.Record)
+	}
+
+	ce2 := toCloudEvent(r, "arn:aws:lambda:us-east-1:123456789012:function:helloWorld")
+	if ce.ID == ce2.ID {
+		t.Error("two calls to toCloudEvent produced the same id, want unique ids per record")
+	}
+}
+
+func TestRecordSource(t *testing.T) {
+	t.Run("explicit cfg.Source wins", func(t *testing.T) {
+		got := recordSource(Config{Source: "my-source"}, json.RawMessage(`{"invokedFunctionArn":"arn:aws:lambda:x"}`))
+		if got != "my-source" {
+			t.Errorf("recordSource = %q, want my-source", got)
+		}
+	})
+
+	t.Run("falls back to invokedFunctionArn", func(t *testing.T) {
+		got := recordSource(Config{}, json.RawMessage(`{"invokedFunctionArn":"arn:aws:lambda:x"}`))
+		if got != "arn:aws:lambda:x" {
+			t.Errorf("recordSource = %q, want arn:aws:lambda:x", got)
+		}
+	})
+
+	t.Run("falls back to generic default", func(t *testing.T) {
+		got := recordSource(Config{}, json.RawMessage(`{"foo":"bar"}`))
+		if got != "aws.lambda" {
+			t.Errorf("recordSource = %q, want aws.lambda", got)
+		}
+	})
+}
+
+func TestGeneratedID(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id := generatedID()
+		if len(id) != 26 {
+			t.Fatalf("generatedID() = %q, want a 26-character ULID", id)
+		}
+		if seen[id] {
+			t.Fatalf("generatedID() produced a duplicate: %q", id)
+		}
+		seen[id] = true
+		for _, c := range id {
+			if !strings.ContainsRune(crockfordAlphabet, c) {
+				t.Fatalf("generatedID() = %q contains non-Crockford character %q", id, c)
+			}
+		}
+	}
+}
+
+func TestEncodeCrockford(t *testing.T) {
+	var zero [16]byte
+	if got := encodeCrockford(zero); got != strings.Repeat("0", 26) {
+		t.Errorf("encodeCrockford(zero) = %q, want 26 zeros", got)
+	}
+
+	var ff [16]byte
+	for i := range ff {
+		ff[i] = 0xff
+	}
+	got := encodeCrockford(ff)
+	if len(got) != 26 {
+		t.Fatalf("encodeCrockford(ff) = %q, want length 26", got)
+	}
+	for _, c := range got {
+		if !strings.ContainsRune(crockfordAlphabet, c) {
+			t.Fatalf("encodeCrockford(ff) = %q contains non-Crockford character %q", got, c)
+		}
+	}
+}
+
+func TestMarshalRecords(t *testing.T) {
+	records := []extensions.TelemetryRecord{
+		{Time: "2024-01-01T00:00:00Z", Type: "function", Record: json.RawMessage(`"hello"`)},
+	}
+
+	t.Run("raw", func(t *testing.T) {
+		out, err := marshalRecords(Config{}, records)
+		if err != nil {
+			t.Fatalf("marshalRecords failed: %v", err)
+		}
+		if len(out) != 1 {
+			t.Fatalf("len(out) = %d, want 1", len(out))
+		}
+		var got extensions.TelemetryRecord
+		if err := json.Unmarshal(out[0], &got); err != nil {
+			t.Fatalf("failed to unmarshal output: %v", err)
+		}
+		if got.Type != "function" {
+			t.Errorf("Type = %q, want function", got.Type)
+		}
+	})
+
+	t.Run("cloudevents structured", func(t *testing.T) {
+		out, err := marshalRecords(Config{PayloadFormat: CloudEventsStructured}, records)
+		if err != nil {
+			t.Fatalf("marshalRecords failed: %v", err)
+		}
+		var ce CloudEvent
+		if err := json.Unmarshal(out[0], &ce); err != nil {
+			t.Fatalf("failed to unmarshal output: %v", err)
+		}
+		if ce.Type != "aws.lambda.telemetry.function" {
+			t.Errorf("Type = %q, want aws.lambda.telemetry.function", ce.Type)
+		}
+	})
+}
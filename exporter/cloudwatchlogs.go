@@ -0,0 +1,71 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+// CloudWatchLogsExporter forwards telemetry records to a CloudWatch Logs
+// log stream via PutLogEvents. The log group and stream must already
+// exist; this exporter does not create them.
+type CloudWatchLogsExporter struct {
+	client        *cloudwatchlogs.Client
+	logGroupName  string
+	logStreamName string
+	cfg           Config
+}
+
+// NewCloudWatchLogsExporter creates a CloudWatchLogsExporter using
+// cfg.LogGroupName and cfg.LogStreamName.
+func NewCloudWatchLogsExporter(ctx context.Context, cfg Config) (*CloudWatchLogsExporter, error) {
+	if cfg.LogGroupName == "" || cfg.LogStreamName == "" {
+		return nil, fmt.Errorf("cloudwatchlogs exporter requires LogGroupName and LogStreamName")
+	}
+	awscfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	return &CloudWatchLogsExporter{
+		client:        cloudwatchlogs.NewFromConfig(awscfg),
+		logGroupName:  cfg.LogGroupName,
+		logStreamName: cfg.LogStreamName,
+		cfg:           cfg,
+	}, nil
+}
+
+func (e *CloudWatchLogsExporter) HandleTelemetry(ctx context.Context, records []extensions.TelemetryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	lines, err := marshalRecords(e.cfg, records)
+	if err != nil {
+		return err
+	}
+	events := make([]types.InputLogEvent, 0, len(records))
+	for i, b := range lines {
+		ts := time.Now()
+		if parsed, err := time.Parse(time.RFC3339Nano, records[i].Time); err == nil {
+			ts = parsed
+		}
+		events = append(events, types.InputLogEvent{
+			Message:   aws.String(string(b)),
+			Timestamp: aws.Int64(ts.UnixMilli()),
+		})
+	}
+	_, err = e.client.PutLogEvents(ctx, &cloudwatchlogs.PutLogEventsInput{
+		LogGroupName:  aws.String(e.logGroupName),
+		LogStreamName: aws.String(e.logStreamName),
+		LogEvents:     events,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put log events: %w", err)
+	}
+	return nil
+}
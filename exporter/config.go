@@ -0,0 +1,72 @@
+// Package exporter provides ready-made extensions.TelemetryHandler
+// implementations for forwarding Lambda telemetry records to common
+// destinations, selectable via Config.
+package exporter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+// Type selects which extensions.TelemetryHandler implementation New
+// builds.
+type Type string
+
+const (
+	Stdout         Type = "stdout"
+	CloudWatchLogs Type = "cloudwatchlogs"
+	Firehose       Type = "firehose"
+	S3             Type = "s3"
+	HTTP           Type = "http"
+)
+
+// Config configures the exporter built by New. Only the fields relevant to
+// Type need to be set.
+type Config struct {
+	Type Type
+
+	// PayloadFormat selects how records are re-serialized before being
+	// written to the destination. Defaults to Raw.
+	PayloadFormat PayloadFormat
+
+	// Source is used as the CloudEvents "source" attribute when
+	// PayloadFormat is one of the CloudEvents formats. If empty, the
+	// record's own invokedFunctionArn is used when present.
+	Source string
+
+	// CloudWatchLogs
+	LogGroupName  string
+	LogStreamName string
+
+	// Firehose
+	DeliveryStreamName string
+
+	// S3
+	Bucket        string
+	Prefix        string
+	FlushInterval time.Duration
+
+	// HTTP
+	URL string
+}
+
+// New builds the extensions.TelemetryHandler selected by cfg.Type.
+func New(ctx context.Context, cfg Config) (extensions.TelemetryHandler, error) {
+	switch cfg.Type {
+	case Stdout, "":
+		return NewStdoutExporter(cfg), nil
+	case CloudWatchLogs:
+		return NewCloudWatchLogsExporter(ctx, cfg)
+	case Firehose:
+		return NewFirehoseExporter(ctx, cfg)
+	case S3:
+		return NewS3Exporter(ctx, cfg)
+	case HTTP:
+		return NewHTTPExporter(cfg)
+	default:
+		return nil, fmt.Errorf("unknown exporter type: %s", cfg.Type)
+	}
+}
@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewDispatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stdout default", func(t *testing.T) {
+		h, err := New(ctx, Config{})
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if _, ok := h.(*StdoutExporter); !ok {
+			t.Fatalf("New returned %T, want *StdoutExporter", h)
+		}
+	})
+
+	t.Run("stdout explicit", func(t *testing.T) {
+		h, err := New(ctx, Config{Type: Stdout})
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if _, ok := h.(*StdoutExporter); !ok {
+			t.Fatalf("New returned %T, want *StdoutExporter", h)
+		}
+	})
+
+	t.Run("http", func(t *testing.T) {
+		h, err := New(ctx, Config{Type: HTTP, URL: "http://example.invalid/telemetry"})
+		if err != nil {
+			t.Fatalf("New failed: %v", err)
+		}
+		if _, ok := h.(*HTTPExporter); !ok {
+			t.Fatalf("New returned %T, want *HTTPExporter", h)
+		}
+	})
+
+	t.Run("http missing URL", func(t *testing.T) {
+		if _, err := New(ctx, Config{Type: HTTP}); err == nil {
+			t.Fatal("expected an error for a missing URL, got nil")
+		}
+	})
+
+	t.Run("cloudwatchlogs missing fields", func(t *testing.T) {
+		if _, err := New(ctx, Config{Type: CloudWatchLogs}); err == nil {
+			t.Fatal("expected an error for missing LogGroupName/LogStreamName, got nil")
+		}
+	})
+
+	t.Run("firehose missing fields", func(t *testing.T) {
+		if _, err := New(ctx, Config{Type: Firehose}); err == nil {
+			t.Fatal("expected an error for a missing DeliveryStreamName, got nil")
+		}
+	})
+
+	t.Run("s3 missing fields", func(t *testing.T) {
+		if _, err := New(ctx, Config{Type: S3}); err == nil {
+			t.Fatal("expected an error for a missing Bucket, got nil")
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		if _, err := New(ctx, Config{Type: "bogus"}); err == nil {
+			t.Fatal("expected an error for an unknown exporter type, got nil")
+		}
+	})
+}
@@ -0,0 +1,62 @@
+package exporter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/firehose"
+	"github.com/aws/aws-sdk-go-v2/service/firehose/types"
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+// FirehoseExporter forwards telemetry records to a Kinesis Data Firehose
+// delivery stream via PutRecordBatch.
+type FirehoseExporter struct {
+	client             *firehose.Client
+	deliveryStreamName string
+	cfg                Config
+}
+
+// NewFirehoseExporter creates a FirehoseExporter using
+// cfg.DeliveryStreamName.
+func NewFirehoseExporter(ctx context.Context, cfg Config) (*FirehoseExporter, error) {
+	if cfg.DeliveryStreamName == "" {
+		return nil, fmt.Errorf("firehose exporter requires DeliveryStreamName")
+	}
+	awscfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	return &FirehoseExporter{
+		client:             firehose.NewFromConfig(awscfg),
+		deliveryStreamName: cfg.DeliveryStreamName,
+		cfg:                cfg,
+	}, nil
+}
+
+func (e *FirehoseExporter) HandleTelemetry(ctx context.Context, records []extensions.TelemetryRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	lines, err := marshalRecords(e.cfg, records)
+	if err != nil {
+		return err
+	}
+	entries := make([]types.Record, 0, len(records))
+	for _, b := range lines {
+		entries = append(entries, types.Record{Data: append(b, '\n')})
+	}
+	out, err := e.client.PutRecordBatch(ctx, &firehose.PutRecordBatchInput{
+		DeliveryStreamName: aws.String(e.deliveryStreamName),
+		Records:            entries,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put record batch: %w", err)
+	}
+	if aws.ToInt32(out.FailedPutCount) > 0 {
+		return fmt.Errorf("firehose rejected %d of %d records", *out.FailedPutCount, len(records))
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+// HTTPExporter forwards telemetry records to an HTTP endpoint, one request
+// per record, honoring cfg.PayloadFormat: CloudEventsBinary carries the
+// CloudEvents attributes as ce-* headers with the raw record as the body,
+// while Raw and CloudEventsStructured POST a JSON body.
+type HTTPExporter struct {
+	client *http.Client
+	url    string
+	cfg    Config
+}
+
+// NewHTTPExporter creates an HTTPExporter posting to cfg.URL.
+func NewHTTPExporter(cfg Config) (*HTTPExporter, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("http exporter requires URL")
+	}
+	return &HTTPExporter{client: http.DefaultClient, url: cfg.URL, cfg: cfg}, nil
+}
+
+func (e *HTTPExporter) HandleTelemetry(ctx context.Context, records []extensions.TelemetryRecord) error {
+	for _, r := range records {
+		if err := e.send(ctx, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *HTTPExporter) send(ctx context.Context, r extensions.TelemetryRecord) error {
+	var (
+		body    []byte
+		headers map[string]string
+		err     error
+	)
+	switch e.cfg.PayloadFormat {
+	case CloudEventsBinary:
+		ce := toCloudEvent(r, recordSource(e.cfg, r.Record))
+		body = ce.Data
+		headers = map[string]string{
+			"ce-specversion": ce.SpecVersion,
+			"ce-type":        ce.Type,
+			"ce-source":      ce.Source,
+			"ce-id":          ce.ID,
+			"ce-time":        ce.Time,
+			"Content-Type":   ce.DataContentType,
+		}
+	case CloudEventsStructured:
+		body, err = json.Marshal(toCloudEvent(r, recordSource(e.cfg, r.Record)))
+		headers = map[string]string{"Content-Type": "application/cloudevents+json"}
+	default:
+		body, err = json.Marshal(r)
+		headers = map[string]string{"Content-Type": "application/json"}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal telemetry record: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build telemetry request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post telemetry record: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telemetry destination returned status %d", resp.StatusCode)
+	}
+	return nil
+}
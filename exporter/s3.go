@@ -0,0 +1,117 @@
+package exporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+// S3Exporter batches telemetry records and flushes them to S3 as a single
+// JSON-lines object once per cfg.FlushInterval, or when ctx is canceled.
+type S3Exporter struct {
+	client   *s3.Client
+	bucket   string
+	prefix   string
+	interval time.Duration
+	cfg      Config
+
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// NewS3Exporter creates a S3Exporter using cfg.Bucket, cfg.Prefix and
+// cfg.FlushInterval (default 10s), and starts its background flush loop.
+func NewS3Exporter(ctx context.Context, cfg Config) (*S3Exporter, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("s3 exporter requires Bucket")
+	}
+	interval := cfg.FlushInterval
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	awscfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %w", err)
+	}
+	e := &S3Exporter{
+		client:   s3.NewFromConfig(awscfg),
+		bucket:   cfg.Bucket,
+		prefix:   cfg.Prefix,
+		interval: interval,
+		cfg:      cfg,
+	}
+	go e.flushLoop(ctx)
+	return e, nil
+}
+
+func (e *S3Exporter) flushLoop(ctx context.Context) {
+	tk := time.NewTicker(e.interval)
+	defer tk.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if err := e.flush(context.Background()); err != nil {
+				slog.Error("failed to flush telemetry to s3", "error", err)
+			}
+			return
+		case <-tk.C:
+			if err := e.flush(ctx); err != nil {
+				slog.Error("failed to flush telemetry to s3", "error", err)
+			}
+		}
+	}
+}
+
+func (e *S3Exporter) HandleTelemetry(ctx context.Context, records []extensions.TelemetryRecord) error {
+	lines, err := marshalRecords(e.cfg, records)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, line := range lines {
+		e.buf.Write(line)
+		e.buf.WriteByte('\n')
+	}
+	return nil
+}
+
+func (e *S3Exporter) flush(ctx context.Context) error {
+	e.mu.Lock()
+	if e.buf.Len() == 0 {
+		e.mu.Unlock()
+		return nil
+	}
+	body := make([]byte, e.buf.Len())
+	copy(body, e.buf.Bytes())
+	e.buf.Reset()
+	e.mu.Unlock()
+
+	key := fmt.Sprintf("%s%s.jsonl", e.prefix, time.Now().UTC().Format("2006/01/02/15-04-05.000000000"))
+	_, err := e.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(e.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+	})
+	if err != nil {
+		// Put the batch back ahead of anything accumulated since, so a
+		// transient PutObject failure (throttling, a network blip) doesn't
+		// silently drop telemetry; it's retried on the next flush instead.
+		e.mu.Lock()
+		var merged bytes.Buffer
+		merged.Write(body)
+		merged.Write(e.buf.Bytes())
+		e.buf = merged
+		e.mu.Unlock()
+		return fmt.Errorf("failed to put object: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package exporter
+
+import (
+	"context"
+	"io"
+	"os"
+
+	extensions "github.com/fujiwara/lambda-extensions"
+)
+
+// StdoutExporter writes each telemetry record as a line of JSON
+// (JSON-lines) to an io.Writer, os.Stdout by default.
+type StdoutExporter struct {
+	w   io.Writer
+	cfg Config
+}
+
+// NewStdoutExporter creates a StdoutExporter writing to os.Stdout.
+func NewStdoutExporter(cfg Config) *StdoutExporter {
+	return &StdoutExporter{w: os.Stdout, cfg: cfg}
+}
+
+func (e *StdoutExporter) HandleTelemetry(ctx context.Context, records []extensions.TelemetryRecord) error {
+	lines, err := marshalRecords(e.cfg, records)
+	if err != nil {
+		return err
+	}
+	for _, line := range lines {
+		if _, err := e.w.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
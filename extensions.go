@@ -9,7 +9,10 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"syscall"
+	"time"
 )
 
 type EventType string
@@ -45,6 +48,27 @@ type Client struct {
 	CallbackInvoke   func(context.Context, *InvokeEvent) error
 	CallbackShutdown func(context.Context, *ShutdownEvent) error
 
+	// TelemetryReceiver, when set, is used by SubscribeTelemetry to fill
+	// in Destination.URI on subscriptions that don't specify one.
+	TelemetryReceiver *TelemetryReceiver
+
+	// DeadlineSafetyMargin is subtracted from an event's deadline before
+	// it is applied to the context passed to CallbackInvoke and
+	// CallbackShutdown, giving user code time to flush buffers before
+	// Lambda force-kills the sandbox. Defaults to 500ms.
+	DeadlineSafetyMargin time.Duration
+
+	// HandleSignals, when true (the default), makes Run install a signal
+	// handler for SIGTERM and SIGINT and treat them as a shutdown event,
+	// since Lambda delivers SIGTERM to external extensions during
+	// shutdown when the runtime itself is unresponsive.
+	HandleSignals bool
+
+	// RetryPolicy configures the backoff used when Register,
+	// SubscribeTelemetry or the event polling loop hit a retryable
+	// error. Defaults to DefaultRetryPolicy.
+	RetryPolicy RetryPolicy
+
 	extensionId                string
 	client                     *http.Client
 	lambdaExtensionAPIEndpoint string
@@ -60,6 +84,9 @@ func NewClient() (*Client, error) {
 	}
 	c := &Client{
 		Name:                       name,
+		DeadlineSafetyMargin:       500 * time.Millisecond,
+		HandleSignals:              true,
+		RetryPolicy:                DefaultRetryPolicy,
 		client:                     http.DefaultClient,
 		lambdaExtensionAPIEndpoint: "http://" + host + "/2020-01-01/extension",
 		lambdaTelemetryAPIEndpoint: "http://" + host + "/2022-07-01/telemetry",
@@ -82,49 +109,64 @@ func (c *Client) Register(ctx context.Context) error {
 		events = append(events, Shutdown)
 	}
 	b, _ := json.Marshal(registerPayload{Events: events})
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
-	req.Header.Set(lambdaExtensionNameHeader, c.Name)
-	slog.InfoContext(ctx, "registering extension", "url", u, "name", c.Name, "headers", req.Header, "events", events)
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to register extension: %w", err)
-	}
-	defer resp.Body.Close()
+	return withRetry(ctx, c.RetryPolicy, func() error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(b))
+		req.Header.Set(lambdaExtensionNameHeader, c.Name)
+		slog.InfoContext(ctx, "registering extension", "url", u, "name", c.Name, "headers", req.Header, "events", events)
 
-	var result map[string]string
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode register response: %w", err)
-	}
-	slog.InfoContext(ctx, "register status", "status", resp.Status, "response", result)
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to register extension: %w", err)
+		}
+		defer resp.Body.Close()
 
-	c.extensionId = resp.Header.Get(lambdaExtensionIdentifierHeader)
-	if c.extensionId == "" {
-		return fmt.Errorf("extension identifier is empty: %d %v", resp.StatusCode, resp.Header)
-	}
-	slog.InfoContext(ctx, "extension registered", "extension_id", c.extensionId)
-	return nil
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return newAPIError(resp.StatusCode, string(body))
+		}
+
+		var result map[string]string
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return &terminalError{fmt.Errorf("failed to decode register response: %w", err)}
+		}
+		slog.InfoContext(ctx, "register status", "status", resp.Status, "response", result)
+
+		c.extensionId = resp.Header.Get(lambdaExtensionIdentifierHeader)
+		if c.extensionId == "" {
+			return &terminalError{fmt.Errorf("extension identifier is empty: %d %v", resp.StatusCode, resp.Header)}
+		}
+		slog.InfoContext(ctx, "extension registered", "extension_id", c.extensionId)
+		return nil
+	})
 }
 
 func (c *Client) fetchNextEvent(ctx context.Context) (*Event, error) {
 	u := fmt.Sprintf("%s/event/next", c.lambdaExtensionAPIEndpoint)
-	slog.DebugContext(ctx, "getting next event", "url", u, "extension_id", c.extensionId)
-	req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
-	req.Header.Set(lambdaExtensionIdentifierHeader, c.extensionId)
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get next event: %w", err)
-	}
-	defer func() {
-		io.Copy(io.Discard, resp.Body)
-		resp.Body.Close()
-	}()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get next event: %d", resp.StatusCode)
-	}
 	var ev Event
-	if err := json.NewDecoder(resp.Body).Decode(&ev); err != nil {
-		return nil, fmt.Errorf("failed to decode event response: %w", err)
+	err := withRetry(ctx, c.RetryPolicy, func() error {
+		slog.DebugContext(ctx, "getting next event", "url", u, "extension_id", c.extensionId)
+		req, _ := http.NewRequestWithContext(ctx, "GET", u, nil)
+		req.Header.Set(lambdaExtensionIdentifierHeader, c.extensionId)
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to get next event: %w", err)
+		}
+		defer func() {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			return newAPIError(resp.StatusCode, string(body))
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&ev); err != nil {
+			return &terminalError{fmt.Errorf("failed to decode event response: %w", err)}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 	return &ev, nil
 }
@@ -134,21 +176,46 @@ func (c *Client) Run(ctx context.Context) error {
 	if c.extensionId == "" {
 		return fmt.Errorf("extension is not registered. call Register method first")
 	}
+	parent := ctx
+	if c.HandleSignals {
+		var stop context.CancelFunc
+		ctx, stop = signal.NotifyContext(ctx, syscall.SIGTERM, syscall.SIGINT)
+		defer stop()
+	}
 	for {
 		ev, err := c.fetchNextEvent(ctx)
 		if err != nil {
 			select {
 			case <-ctx.Done():
+				if c.HandleSignals && parent.Err() == nil {
+					return c.handleSignalShutdown()
+				}
 				return nil
 			default:
 			}
 			slog.ErrorContext(ctx, "failed to fetch next event", "error", err)
+			// fetchNextEvent's own withRetry already backs off between
+			// attempts, but returns immediately on a terminal error; wait
+			// at least one poll backoff here too so a persistent terminal
+			// failure (e.g. a malformed response, a lingering 403) can't
+			// busy-loop this outer loop.
+			select {
+			case <-ctx.Done():
+				if c.HandleSignals && parent.Err() == nil {
+					return c.handleSignalShutdown()
+				}
+				return nil
+			case <-time.After(c.pollBackoff()):
+			}
 			continue
 		}
 		if ev.Invoke != nil {
 			slog.DebugContext(ctx, "invoke event received")
 			if c.CallbackInvoke != nil {
-				if err := c.CallbackInvoke(ctx, ev.Invoke); err != nil {
+				invokeCtx, cancel := c.deadlineContext(ctx, ev.Invoke.Deadline())
+				err := c.CallbackInvoke(invokeCtx, ev.Invoke)
+				cancel()
+				if err != nil {
 					slog.ErrorContext(ctx, "invoke callback failed", "error", err)
 				}
 			} else {
@@ -157,7 +224,10 @@ func (c *Client) Run(ctx context.Context) error {
 		} else if ev.Shutdown != nil {
 			slog.DebugContext(ctx, "shutdown event received. shutting down extension")
 			if c.CallbackShutdown != nil {
-				if err := c.CallbackShutdown(ctx, ev.Shutdown); err != nil {
+				shutdownCtx, cancel := c.deadlineContext(ctx, ev.Shutdown.Deadline())
+				err := c.CallbackShutdown(shutdownCtx, ev.Shutdown)
+				cancel()
+				if err != nil {
 					slog.ErrorContext(ctx, "shutdown callback failed", "error", err)
 					return fmt.Errorf("shutdown callback failed: %w", err)
 				}
@@ -171,30 +241,77 @@ func (c *Client) Run(ctx context.Context) error {
 	}
 }
 
+// handleSignalShutdown synthesizes a ShutdownEvent for a SIGTERM/SIGINT
+// caught by the signal.NotifyContext installed in Run and invokes
+// CallbackShutdown with it, so extensions can flush telemetry on
+// spot-termination-style shutdowns. ctx is already canceled at this point,
+// so the callback's deadline-bounded context is derived from
+// context.Background() instead.
+func (c *Client) handleSignalShutdown() error {
+	if c.CallbackShutdown == nil {
+		return nil
+	}
+	ev := &ShutdownEvent{
+		EventType:      Shutdown,
+		ShutdownReason: "signal:SIGTERM",
+		DeadlineMs:     int(time.Now().Add(2 * time.Second).UnixMilli()),
+	}
+	shutdownCtx, cancel := c.deadlineContext(context.Background(), ev.Deadline())
+	defer cancel()
+	if err := c.CallbackShutdown(shutdownCtx, ev); err != nil {
+		return fmt.Errorf("shutdown callback failed: %w", err)
+	}
+	return nil
+}
+
+// deadlineContext derives a context bounded by deadline minus
+// c.DeadlineSafetyMargin, so callbacks have time to finish before Lambda
+// force-kills the sandbox. A zero deadline (DeadlineMs unset, as with
+// MockExtensionAPIHandler) leaves ctx unbounded rather than producing an
+// already-expired context.
+func (c *Client) deadlineContext(ctx context.Context, deadline time.Time) (context.Context, context.CancelFunc) {
+	if deadline.IsZero() {
+		return context.WithCancel(ctx)
+	}
+	return context.WithDeadline(ctx, deadline.Add(-c.DeadlineSafetyMargin))
+}
+
 // SubscribeTelemetry subscribes to the telemetry API
 func (c *Client) SubscribeTelemetry(ctx context.Context, subscription *TelemetrySubscription) error {
 	u := c.lambdaTelemetryAPIEndpoint
 	if subscription == nil {
 		subscription = NewDefaultTelemetrySubscription()
+		// NewDefaultTelemetrySubscription already fills in a Destination,
+		// so the URI == "" check below never applies here; replace that
+		// default with c.TelemetryReceiver's URI when a receiver is set.
+		if c.TelemetryReceiver != nil {
+			subscription.Destination.Protocol = "HTTP"
+			subscription.Destination.URI = c.TelemetryReceiver.URI()
+		}
+	} else if subscription.Destination.URI == "" && c.TelemetryReceiver != nil {
+		subscription.Destination.Protocol = "HTTP"
+		subscription.Destination.URI = c.TelemetryReceiver.URI()
 	}
 	s, _ := json.Marshal(subscription)
-	req, _ := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(s))
-	req.Header.Set(lambdaExtensionNameHeader, c.Name)
-	req.Header.Set(lambdaExtensionIdentifierHeader, c.extensionId)
-	slog.InfoContext(ctx, "subscribing telemetry API", "url", u, "name", c.Name, "headers", req.Header, "payload", string(s))
 
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to register extension: %w", err)
-	}
-	defer resp.Body.Close()
-	b, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to subscribe telemetry API: %d %s", resp.StatusCode, string(b))
-	} else {
+	return withRetry(ctx, c.RetryPolicy, func() error {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(s))
+		req.Header.Set(lambdaExtensionNameHeader, c.Name)
+		req.Header.Set(lambdaExtensionIdentifierHeader, c.extensionId)
+		slog.InfoContext(ctx, "subscribing telemetry API", "url", u, "name", c.Name, "headers", req.Header, "payload", string(s))
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to subscribe telemetry API: %w", err)
+		}
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != http.StatusOK {
+			return newAPIError(resp.StatusCode, string(b))
+		}
 		slog.InfoContext(ctx, "subscribed telemetry API", "status", resp.Status, "response", string(b))
-	}
-	return nil
+		return nil
+	})
 }
 
 type TelemetrySubscription struct {
@@ -263,6 +380,16 @@ type InvokeEvent struct {
 	} `json:"tracing"`
 }
 
+// Deadline returns the time by which Lambda expects the invocation to
+// complete, converted from DeadlineMs. It returns the zero time.Time if
+// DeadlineMs is unset.
+func (e *InvokeEvent) Deadline() time.Time {
+	if e.DeadlineMs == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(e.DeadlineMs))
+}
+
 /*
 {
   "eventType": "SHUTDOWN",
@@ -277,6 +404,16 @@ type ShutdownEvent struct {
 	ShutdownReason string    `json:"shutdownReason"`
 }
 
+// Deadline returns the time by which Lambda expects the extension to
+// finish shutting down, converted from DeadlineMs. It returns the zero
+// time.Time if DeadlineMs is unset.
+func (e *ShutdownEvent) Deadline() time.Time {
+	if e.DeadlineMs == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(e.DeadlineMs))
+}
+
 type Event struct {
 	Invoke   *InvokeEvent
 	Shutdown *ShutdownEvent
@@ -0,0 +1,121 @@
+package extensions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy configures exponential backoff with jitter for outbound
+// calls to the Lambda Extensions/Telemetry APIs.
+type RetryPolicy struct {
+	MaxAttempts    int           // default 5
+	InitialDelay   time.Duration // default 100ms
+	MaxDelay       time.Duration // default 5s
+	Multiplier     float64       // default 2.0
+	JitterFraction float64       // default 0.2, applied as +/- a fraction of the delay
+}
+
+// DefaultRetryPolicy is used by Client when RetryPolicy is left zero.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    5,
+	InitialDelay:   100 * time.Millisecond,
+	MaxDelay:       5 * time.Second,
+	Multiplier:     2.0,
+	JitterFraction: 0.2,
+}
+
+// pollBackoff returns the minimum delay Run's event poll loop waits
+// before calling fetchNextEvent again after any failure, including
+// terminal ones that withRetry itself won't retry.
+func (c *Client) pollBackoff() time.Duration {
+	if c.RetryPolicy.InitialDelay > 0 {
+		return c.RetryPolicy.InitialDelay
+	}
+	return DefaultRetryPolicy.InitialDelay
+}
+
+// APIError is returned when a Lambda Extensions/Telemetry API call
+// completes with a non-2xx response. Retryable reports whether the
+// request may be retried: 5xx and 429 responses are retryable, other 4xx
+// responses are not.
+type APIError struct {
+	StatusCode int
+	Body       string
+	Retryable  bool
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("extensions api error: status=%d body=%q", e.StatusCode, e.Body)
+}
+
+func newAPIError(statusCode int, body string) *APIError {
+	return &APIError{
+		StatusCode: statusCode,
+		Body:       body,
+		Retryable:  statusCode == http.StatusTooManyRequests || statusCode >= 500,
+	}
+}
+
+// terminalError marks an error as not retryable regardless of its
+// underlying type, e.g. a JSON decode failure on an otherwise successful
+// response.
+type terminalError struct {
+	err error
+}
+
+func (e *terminalError) Error() string { return e.err.Error() }
+func (e *terminalError) Unwrap() error { return e.err }
+
+// withRetry calls fn, retrying on retryable errors with exponential
+// backoff and jitter per policy. Network/transport errors and *APIError
+// with Retryable true are retried; a *terminalError or an *APIError with
+// Retryable false are returned immediately. Retries stop as soon as ctx is
+// done.
+func withRetry(ctx context.Context, policy RetryPolicy, fn func() error) error {
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy
+	}
+	delay := policy.InitialDelay
+	var err error
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		if err = fn(); err == nil {
+			return nil
+		}
+		if attempt == policy.MaxAttempts || !retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(jitter(delay, policy.JitterFraction)):
+		}
+		if delay = time.Duration(float64(delay) * policy.Multiplier); delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+	return err
+}
+
+func retryable(err error) bool {
+	var term *terminalError
+	if errors.As(err, &term) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Retryable
+	}
+	return true
+}
+
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+	delta := float64(d) * fraction
+	return d + time.Duration((rand.Float64()*2-1)*delta)
+}
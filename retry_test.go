@@ -0,0 +1,126 @@
+package extensions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryable(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"generic error", errors.New("boom"), true},
+		{"terminal error", &terminalError{errors.New("boom")}, false},
+		{"retryable api error", &APIError{StatusCode: 503, Retryable: true}, true},
+		{"non-retryable api error", &APIError{StatusCode: 400, Retryable: false}, false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := retryable(c.err); got != c.want {
+				t.Errorf("retryable(%v) = %v, want %v", c.err, got, c.want)
+			}
+		})
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	cases := []struct {
+		status int
+		want   bool
+	}{
+		{500, true},
+		{503, true},
+		{429, true},
+		{400, false},
+		{404, false},
+	}
+	for _, c := range cases {
+		if got := newAPIError(c.status, "").Retryable; got != c.want {
+			t.Errorf("newAPIError(%d).Retryable = %v, want %v", c.status, got, c.want)
+		}
+	}
+}
+
+func TestJitter(t *testing.T) {
+	d := 100 * time.Millisecond
+	for i := 0; i < 100; i++ {
+		if got := jitter(d, 0.2); got < 80*time.Millisecond || got > 120*time.Millisecond {
+			t.Fatalf("jitter(%v, 0.2) = %v, out of expected bounds", d, got)
+		}
+	}
+	if got := jitter(d, 0); got != d {
+		t.Errorf("jitter(%v, 0) = %v, want %v unchanged", d, got, d)
+	}
+}
+
+func TestWithRetrySucceedsAfterRetries(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, Multiplier: 2}
+	attempts := 0
+	err := withRetry(context.Background(), policy, func() error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("transient")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry failed: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetryStopsOnTerminalError(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, InitialDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, Multiplier: 2}
+	attempts := 0
+	err := withRetry(context.Background(), policy, func() error {
+		attempts++
+		return &terminalError{errors.New("bad request")}
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts != 1 {
+		t.Errorf("expected 1 attempt for a terminal error, got %d", attempts)
+	}
+}
+
+func TestWithRetryExhaustsMaxAttempts(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond, Multiplier: 2}
+	attempts := 0
+	err := withRetry(context.Background(), policy, func() error {
+		attempts++
+		return errors.New("always fails")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestWithRetryRespectsContextCancellation(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialDelay: 50 * time.Millisecond, MaxDelay: time.Second, Multiplier: 2}
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	err := withRetry(ctx, policy, func() error {
+		attempts++
+		return errors.New("always fails")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if attempts >= 10 {
+		t.Errorf("expected early exit on context cancellation, got %d attempts", attempts)
+	}
+}
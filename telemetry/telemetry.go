@@ -0,0 +1,120 @@
+// Package telemetry provides typed decoding for the "record" field of
+// Lambda Telemetry API records, keyed by their "type".
+package telemetry
+
+import "encoding/json"
+
+// PlatformStartRecord is emitted when a function invocation begins.
+type PlatformStartRecord struct {
+	RequestID string `json:"requestId"`
+	Version   string `json:"version"`
+}
+
+// PlatformRuntimeDoneRecord is emitted when the runtime finishes handling
+// an invocation, successfully or not.
+type PlatformRuntimeDoneRecord struct {
+	RequestID string `json:"requestId"`
+	Status    string `json:"status"`
+	Metrics   struct {
+		DurationMs float64 `json:"durationMs"`
+	} `json:"metrics"`
+}
+
+// PlatformReportRecord is emitted once the runtime and all extensions have
+// finished processing an invocation, reporting billing metrics.
+type PlatformReportRecord struct {
+	RequestID string `json:"requestId"`
+	Status    string `json:"status"`
+	Metrics   struct {
+		DurationMs       float64 `json:"durationMs"`
+		BilledDurationMs float64 `json:"billedDurationMs"`
+		MemorySizeMB     float64 `json:"memorySizeMB"`
+		MaxMemoryUsedMB  float64 `json:"maxMemoryUsedMB"`
+		InitDurationMs   float64 `json:"initDurationMs,omitempty"`
+	} `json:"metrics"`
+}
+
+// PlatformInitStartRecord is emitted when the runtime starts initializing.
+type PlatformInitStartRecord struct {
+	InitializationType string `json:"initializationType"`
+	Phase              string `json:"phase"`
+	RuntimeVersion     string `json:"runtimeVersion,omitempty"`
+}
+
+// PlatformInitRuntimeDoneRecord is emitted when runtime initialization
+// completes.
+type PlatformInitRuntimeDoneRecord struct {
+	InitializationType string `json:"initializationType"`
+	Phase              string `json:"phase"`
+	Status             string `json:"status"`
+}
+
+// FunctionRecord is a log line written by the function code itself.
+type FunctionRecord string
+
+// ExtensionRecord is a log line written by an extension.
+type ExtensionRecord string
+
+// UnknownRecord carries the raw JSON of a record whose type isn't
+// recognized by DecodeRecord, preserving forward-compatibility with
+// record types AWS adds in the future.
+type UnknownRecord struct {
+	Type string
+	Raw  json.RawMessage
+}
+
+// DecodeRecord decodes raw into the concrete type documented for
+// recordType (e.g. "platform.start", "platform.runtimeDone",
+// "platform.report", "function", "extension"), returning a pointer to
+// that type. Record types it doesn't recognize decode to *UnknownRecord
+// with no error.
+func DecodeRecord(raw json.RawMessage, recordType string) (any, error) {
+	switch recordType {
+	case "platform.start":
+		var r PlatformStartRecord
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return nil, err
+		}
+		return &r, nil
+	case "platform.runtimeDone":
+		var r PlatformRuntimeDoneRecord
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return nil, err
+		}
+		return &r, nil
+	case "platform.report":
+		var r PlatformReportRecord
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return nil, err
+		}
+		return &r, nil
+	case "platform.initStart":
+		var r PlatformInitStartRecord
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return nil, err
+		}
+		return &r, nil
+	case "platform.initRuntimeDone":
+		var r PlatformInitRuntimeDoneRecord
+		if err := json.Unmarshal(raw, &r); err != nil {
+			return nil, err
+		}
+		return &r, nil
+	case "function":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		r := FunctionRecord(s)
+		return &r, nil
+	case "extension":
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, err
+		}
+		r := ExtensionRecord(s)
+		return &r, nil
+	default:
+		return &UnknownRecord{Type: recordType, Raw: raw}, nil
+	}
+}
@@ -0,0 +1,73 @@
+package telemetry_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/fujiwara/lambda-extensions/telemetry"
+)
+
+func TestDecodeRecordPlatformReport(t *testing.T) {
+	raw := json.RawMessage(`{
+		"requestId": "req-1",
+		"status": "success",
+		"metrics": {
+			"durationMs": 12.3,
+			"billedDurationMs": 13,
+			"memorySizeMB": 128,
+			"maxMemoryUsedMB": 64,
+			"initDurationMs": 100.5
+		}
+	}`)
+	v, err := telemetry.DecodeRecord(raw, "platform.report")
+	if err != nil {
+		t.Fatalf("DecodeRecord failed: %v", err)
+	}
+	r, ok := v.(*telemetry.PlatformReportRecord)
+	if !ok {
+		t.Fatalf("DecodeRecord returned %T, want *telemetry.PlatformReportRecord", v)
+	}
+	if r.RequestID != "req-1" || r.Status != "success" {
+		t.Errorf("unexpected record: %+v", r)
+	}
+	if r.Metrics.BilledDurationMs != 13 || r.Metrics.InitDurationMs != 100.5 {
+		t.Errorf("unexpected metrics: %+v", r.Metrics)
+	}
+}
+
+func TestDecodeRecordFunction(t *testing.T) {
+	raw := json.RawMessage(`"hello from the function"`)
+	v, err := telemetry.DecodeRecord(raw, "function")
+	if err != nil {
+		t.Fatalf("DecodeRecord failed: %v", err)
+	}
+	r, ok := v.(*telemetry.FunctionRecord)
+	if !ok {
+		t.Fatalf("DecodeRecord returned %T, want *telemetry.FunctionRecord", v)
+	}
+	if string(*r) != "hello from the function" {
+		t.Errorf("unexpected record: %q", *r)
+	}
+}
+
+func TestDecodeRecordUnknownType(t *testing.T) {
+	raw := json.RawMessage(`{"foo":"bar"}`)
+	v, err := telemetry.DecodeRecord(raw, "platform.someFutureType")
+	if err != nil {
+		t.Fatalf("DecodeRecord failed: %v", err)
+	}
+	r, ok := v.(*telemetry.UnknownRecord)
+	if !ok {
+		t.Fatalf("DecodeRecord returned %T, want *telemetry.UnknownRecord", v)
+	}
+	if r.Type != "platform.someFutureType" || string(r.Raw) != string(raw) {
+		t.Errorf("unexpected record: %+v", r)
+	}
+}
+
+func TestDecodeRecordDecodeError(t *testing.T) {
+	raw := json.RawMessage(`not valid json`)
+	if _, err := telemetry.DecodeRecord(raw, "platform.report"); err == nil {
+		t.Fatal("expected an error for malformed JSON, got nil")
+	}
+}
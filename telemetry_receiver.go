@@ -0,0 +1,110 @@
+package extensions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/fujiwara/lambda-extensions/telemetry"
+)
+
+// TelemetryRecord is a single record delivered by the Lambda Telemetry API.
+//
+// Record is left as raw JSON because its shape depends on Type (one of
+// "platform.*", "function" or "extension"); call Decode to obtain a typed
+// struct from the telemetry subpackage.
+type TelemetryRecord struct {
+	Time   string          `json:"time"`
+	Type   string          `json:"type"`
+	Record json.RawMessage `json:"record"`
+}
+
+// Decode decodes Record into the concrete type telemetry.DecodeRecord
+// documents for Type, falling back to *telemetry.UnknownRecord for
+// record types it doesn't recognize.
+func (r TelemetryRecord) Decode() (any, error) {
+	return telemetry.DecodeRecord(r.Record, r.Type)
+}
+
+// TelemetryHandler receives batches of telemetry records as delivered by
+// the Lambda Telemetry API. Implementations are expected to forward or
+// export the records; see the exporter subpackage for ready-made ones.
+type TelemetryHandler interface {
+	HandleTelemetry(ctx context.Context, records []TelemetryRecord) error
+}
+
+// TelemetryReceiver is an HTTP server that receives telemetry records
+// pushed by the Lambda Telemetry API and dispatches them to a
+// TelemetryHandler.
+type TelemetryReceiver struct {
+	// Addr is the listen address, e.g. ":8080". Defaults to
+	// fmt.Sprintf(":%d", DefaultTelemetryPort).
+	Addr string
+
+	// Handler is called with each batch of records received.
+	Handler TelemetryHandler
+
+	server *http.Server
+}
+
+// NewTelemetryReceiver creates a TelemetryReceiver listening on
+// DefaultTelemetryPort that dispatches received records to handler.
+func NewTelemetryReceiver(handler TelemetryHandler) *TelemetryReceiver {
+	return &TelemetryReceiver{
+		Addr:    fmt.Sprintf(":%d", DefaultTelemetryPort),
+		Handler: handler,
+	}
+}
+
+// URI returns the destination URI to hand to SubscribeTelemetry, using the
+// hostname Lambda resolves for extensions running alongside the function.
+func (r *TelemetryReceiver) URI() string {
+	port := r.Addr
+	if i := strings.LastIndex(port, ":"); i >= 0 {
+		port = port[i+1:]
+	}
+	return fmt.Sprintf("http://sandbox.localdomain:%s", port)
+}
+
+// Start starts the receiver's HTTP server and blocks until ctx is canceled
+// or the server fails, shutting the server down gracefully on ctx.Done().
+func (r *TelemetryReceiver) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", r.handle)
+	r.server = &http.Server{Addr: r.Addr, Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- r.server.ListenAndServe()
+	}()
+
+	select {
+	case <-ctx.Done():
+		return r.server.Shutdown(context.Background())
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("telemetry receiver failed: %w", err)
+		}
+		return nil
+	}
+}
+
+func (r *TelemetryReceiver) handle(w http.ResponseWriter, req *http.Request) {
+	var records []TelemetryRecord
+	if err := json.NewDecoder(req.Body).Decode(&records); err != nil {
+		slog.ErrorContext(req.Context(), "failed to decode telemetry payload", "error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if r.Handler != nil {
+		if err := r.Handler.HandleTelemetry(req.Context(), records); err != nil {
+			slog.ErrorContext(req.Context(), "telemetry handler failed", "error", err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,59 @@
+package extensions
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type fakeTelemetryHandler struct {
+	records []TelemetryRecord
+	err     error
+}
+
+func (h *fakeTelemetryHandler) HandleTelemetry(ctx context.Context, records []TelemetryRecord) error {
+	h.records = records
+	return h.err
+}
+
+func TestTelemetryReceiverHandle(t *testing.T) {
+	t.Run("valid batch dispatch", func(t *testing.T) {
+		h := &fakeTelemetryHandler{}
+		r := NewTelemetryReceiver(h)
+		body := `[{"time":"2024-01-01T00:00:00Z","type":"function","record":"hello"}]`
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		r.handle(w, req)
+		if w.Code != 200 {
+			t.Fatalf("status = %d, want 200", w.Code)
+		}
+		if len(h.records) != 1 || h.records[0].Type != "function" {
+			t.Fatalf("unexpected records dispatched: %+v", h.records)
+		}
+	})
+
+	t.Run("malformed JSON", func(t *testing.T) {
+		h := &fakeTelemetryHandler{}
+		r := NewTelemetryReceiver(h)
+		req := httptest.NewRequest("POST", "/", strings.NewReader("not json"))
+		w := httptest.NewRecorder()
+		r.handle(w, req)
+		if w.Code != 400 {
+			t.Fatalf("status = %d, want 400", w.Code)
+		}
+	})
+
+	t.Run("handler error", func(t *testing.T) {
+		h := &fakeTelemetryHandler{err: errors.New("boom")}
+		r := NewTelemetryReceiver(h)
+		body := `[{"time":"2024-01-01T00:00:00Z","type":"function","record":"hello"}]`
+		req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		r.handle(w, req)
+		if w.Code != 500 {
+			t.Fatalf("status = %d, want 500", w.Code)
+		}
+	})
+}